@@ -0,0 +1,135 @@
+package dynamixel
+
+import "fmt"
+
+// Bus coordinates operations across many servos sharing a Networker,
+// batching them into a single SYNC_WRITE or BULK_READ instruction instead of
+// one WriteData/ReadData round trip per servo. This matters for gait/arm
+// control, where per-servo round trips dominate loop time.
+type Bus struct {
+	Network  Networker
+	Protocol Protocol
+}
+
+// NewBus returns a Bus that sends batched instructions over network, framed
+// for protocol. Every servo driven through this Bus (directly or via a
+// Group) must share that protocol; SYNC_WRITE and BULK_READ can't mix
+// protocols in one packet.
+func NewBus(network Networker, protocol Protocol) *Bus {
+	return &Bus{Network: network, Protocol: protocol}
+}
+
+// SyncWrite writes reg to every servo in values (keyed by servo ID) in a
+// single instruction, framed for b.Protocol. Every servo addressed must
+// share that protocol; SYNC_WRITE can't mix protocols in one packet.
+func (b *Bus) SyncWrite(reg Register, values map[uint8]int) error {
+	encoded := make(map[uint8][]byte, len(values))
+
+	for id, v := range values {
+		switch reg.length {
+		case 1:
+			encoded[id] = []byte{low(v)}
+		case 2:
+			encoded[id] = []byte{low(v), high(v)}
+		default:
+			return fmt.Errorf("invalid register length: %d", reg.length)
+		}
+	}
+
+	return b.Network.SyncWrite(reg.address, encoded, b.Protocol)
+}
+
+// BulkRead reads reg from every servo in ids in a single round trip, framed
+// for b.Protocol. Every servo addressed must share that protocol.
+func (b *Bus) BulkRead(reg Register, ids []uint8) (map[uint8]int, error) {
+	reads := make([]BulkReadSpec, len(ids))
+	for i, id := range ids {
+		reads[i] = BulkReadSpec{ID: id, Addr: reg.address, Length: reg.length}
+	}
+
+	raw, err := b.Network.BulkRead(reads, b.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[uint8]int, len(raw))
+	for id, bs := range raw {
+		v, err := bytesToInt(bs)
+		if err != nil {
+			return nil, err
+		}
+		values[id] = v
+	}
+
+	return values, nil
+}
+
+// Group is a fixed set of servos driven together through a Bus.
+type Group struct {
+	Bus    *Bus
+	Servos []*DynamixelServo
+}
+
+// NewGroup returns a Group of servos driven through bus.
+func NewGroup(bus *Bus, servos ...*DynamixelServo) *Group {
+	return &Group{Bus: bus, Servos: servos}
+}
+
+// MoveTo sets the goal position (in degrees) for each servo in targets using
+// a single SYNC_WRITE, carrying each servo's current MovingSpeed along with
+// it so the write covers both registers at once.
+func (g *Group) MoveTo(targets map[*DynamixelServo]float64) error {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var posReg Register
+	first := true
+
+	values := make(map[uint8][]byte, len(targets))
+	positions := make(map[*DynamixelServo]int, len(targets))
+
+	for s, angle := range targets {
+		pr, err := s.reg(goalPosition)
+		if err != nil {
+			return err
+		}
+		sr, err := s.reg(movingSpeed)
+		if err != nil {
+			return err
+		}
+		if sr.address != pr.address+byte(pr.length) {
+			return fmt.Errorf("movingSpeed and goalPosition aren't adjacent on %s; can't sync-write them together", s.model.Name())
+		}
+		if s.Protocol() != g.Bus.Protocol {
+			return fmt.Errorf("%s uses a different protocol than its bus; can't share a SYNC_WRITE", s.model.Name())
+		}
+
+		if first {
+			posReg = pr
+			first = false
+		} else if pr.address != posReg.address {
+			return fmt.Errorf("goalPosition address differs between servos in group; can't share a SYNC_WRITE")
+		}
+
+		speed, err := s.MovingSpeed()
+		if err != nil {
+			return err
+		}
+
+		pos := s.angleToPos(normalizeAngle(angle))
+		values[s.Ident] = []byte{low(pos), high(pos), low(speed), high(speed)}
+		positions[s] = pos
+	}
+
+	if err := g.Bus.Network.SyncWrite(posReg.address, values, g.Bus.Protocol); err != nil {
+		return err
+	}
+
+	for s, pos := range positions {
+		s.cache[posReg.address] = low(pos)
+		s.cache[posReg.address+1] = high(pos)
+	}
+
+	return nil
+}
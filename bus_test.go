@@ -0,0 +1,85 @@
+package dynamixel
+
+import "testing"
+
+func TestBusSyncWriteAndBulkRead(t *testing.T) {
+	net := newFakeNetworker(tableSizeOf(AX12A.ControlTable()))
+	bus := NewBus(net, protocol1{})
+
+	reg, ok := AX12A.ControlTable()[goalPosition]
+	if !ok {
+		t.Fatalf("AX12A has no goalPosition register")
+	}
+
+	if err := bus.SyncWrite(*reg, map[uint8]int{1: 512, 2: 256}); err != nil {
+		t.Fatalf("SyncWrite: %v", err)
+	}
+
+	got, err := bus.BulkRead(*reg, []uint8{1, 2})
+	if err != nil {
+		t.Fatalf("BulkRead: %v", err)
+	}
+	if got[1] != 512 || got[2] != 256 {
+		t.Errorf("BulkRead() = %v, want {1:512, 2:256}", got)
+	}
+}
+
+func TestGroupMoveToRejectsMismatchedProtocol(t *testing.T) {
+	axNet := newFakeNetworker(tableSizeOf(AX12A.ControlTable()))
+	ax, err := NewServo(axNet, AX12A, 1)
+	if err != nil {
+		t.Fatalf("NewServo(AX12A): %v", err)
+	}
+
+	xlNet := newFakeNetworker(tableSizeOf(XL320.ControlTable()))
+	xl, err := NewServo(xlNet, XL320, 2)
+	if err != nil {
+		t.Fatalf("NewServo(XL320): %v", err)
+	}
+
+	// ax and xl don't even share a Networker, but that's beside the point:
+	// MoveTo should reject the group before it ever tries to share a
+	// SYNC_WRITE across mismatched protocols.
+	bus := NewBus(axNet, protocol1{})
+	group := NewGroup(bus, ax, xl)
+
+	if err := group.MoveTo(map[*DynamixelServo]float64{ax: 10, xl: 10}); err == nil {
+		t.Fatal("MoveTo() = nil error, want one for a protocol mismatch")
+	}
+}
+
+func TestGroupMoveToRejectsNonAdjacentRegisters(t *testing.T) {
+	net := newFakeNetworker(tableSizeOf(AX12A.ControlTable()))
+	servo, err := NewServo(net, AX12A, 1)
+	if err != nil {
+		t.Fatalf("NewServo: %v", err)
+	}
+
+	// Perturb a private copy of AX12A's control table so goalPosition and
+	// movingSpeed are no longer adjacent, without mutating the shared
+	// package-level axControlTable other tests rely on.
+	table := map[registerID]*Register{}
+	for id, r := range AX12A.ControlTable() {
+		cp := *r
+		table[id] = &cp
+	}
+	table[movingSpeed].address += 2
+	servo.model = perturbedModel{Model: AX12A, table: table}
+
+	bus := NewBus(net, protocol1{})
+	group := NewGroup(bus, servo)
+
+	if err := group.MoveTo(map[*DynamixelServo]float64{servo: 10}); err == nil {
+		t.Fatal("MoveTo() = nil error, want one for non-adjacent registers")
+	}
+}
+
+// perturbedModel overrides ControlTable on top of an embedded Model, for
+// tests that need to tweak a single model's table without mutating the
+// shared package-level one.
+type perturbedModel struct {
+	Model
+	table map[registerID]*Register
+}
+
+func (m perturbedModel) ControlTable() map[registerID]*Register { return m.table }
@@ -0,0 +1,144 @@
+package dynamixel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DictEntry describes a single register in a Dictionary, in the spirit of
+// IngeniaLink's servo dictionary entries: enough to drive the wire protocol
+// (address, length, access) and enough to present an engineering-unit value
+// to callers (units, scale, offset).
+type DictEntry struct {
+	Identifier string  `json:"identifier"`
+	Address    byte    `json:"address"`
+	Length     int     `json:"length"`
+	Access     access  `json:"access"` // ro, rw or wo
+	Cacheable  bool    `json:"cacheable"`
+	Min        int     `json:"min"`
+	Max        int     `json:"max"`
+	Units      string  `json:"units,omitempty"`
+	Scale      float64 `json:"scale,omitempty"`
+	Offset     float64 `json:"offset,omitempty"`
+
+	// Subnode marks which section of the control table the entry lives in,
+	// e.g. "EEPROM" or "RAM", so callers can tell what's persistent.
+	Subnode string `json:"subnode"`
+}
+
+// Register converts the entry to the internal Register representation used
+// by getRegister/setRegister.
+func (e DictEntry) Register() Register {
+	return Register{
+		address:   e.Address,
+		length:    e.Length,
+		access:    e.Access,
+		cacheable: e.Cacheable,
+		min:       e.Min,
+		max:       e.Max,
+	}
+}
+
+// toEngineering converts a raw register value to an engineering-unit value,
+// applying the entry's scale and offset (value*scale + offset). Entries
+// without a scale are passed through unchanged.
+func (e DictEntry) toEngineering(raw int) float64 {
+	scale := e.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return float64(raw)*scale + e.Offset
+}
+
+// toRaw converts an engineering-unit value back to a raw register value.
+func (e DictEntry) toRaw(value float64) int {
+	scale := e.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return int((value - e.Offset) / scale)
+}
+
+// Dictionary is a servo's control table, loaded at runtime rather than
+// compiled in, so that newer or third-party models can be supported by
+// shipping a file instead of editing Go.
+type Dictionary struct {
+	Name    string      `json:"name"`
+	Entries []DictEntry `json:"entries"`
+}
+
+// Entry looks up an entry by identifier.
+func (d *Dictionary) Entry(identifier string) (DictEntry, bool) {
+	for _, e := range d.Entries {
+		if e.Identifier == identifier {
+			return e, true
+		}
+	}
+	return DictEntry{}, false
+}
+
+// TableSize returns the number of bytes needed to cache every register in
+// the dictionary, i.e. the highest (address + length) of any entry.
+func (d *Dictionary) TableSize() int {
+	size := 0
+	for _, e := range d.Entries {
+		if end := int(e.Address) + e.Length; end > size {
+			size = end
+		}
+	}
+	return size
+}
+
+// validate checks that a Dictionary is well formed: every entry has an
+// identifier, a length of 1 or 2 bytes, a recognised access mode, and no two
+// entries overlap in the control table.
+func (d *Dictionary) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("dictionary is missing a name")
+	}
+
+	seen := map[string]bool{}
+	occupied := map[int]string{}
+
+	for _, e := range d.Entries {
+		if e.Identifier == "" {
+			return fmt.Errorf("dictionary %q has an entry with no identifier", d.Name)
+		}
+		if seen[e.Identifier] {
+			return fmt.Errorf("dictionary %q has a duplicate identifier: %s", d.Name, e.Identifier)
+		}
+		seen[e.Identifier] = true
+
+		if e.Length != 1 && e.Length != 2 {
+			return fmt.Errorf("entry %q has invalid length: %d", e.Identifier, e.Length)
+		}
+		if e.Access != ro && e.Access != rw && e.Access != wo {
+			return fmt.Errorf("entry %q has invalid access mode: %d", e.Identifier, e.Access)
+		}
+
+		for i := 0; i < e.Length; i++ {
+			addr := int(e.Address) + i
+			if owner, ok := occupied[addr]; ok {
+				return fmt.Errorf("entry %q overlaps %q at address %d", e.Identifier, owner, addr)
+			}
+			occupied[addr] = e.Identifier
+		}
+	}
+
+	return nil
+}
+
+// LoadDictionary reads a JSON-encoded Dictionary from r and validates it.
+func LoadDictionary(r io.Reader) (*Dictionary, error) {
+	var d Dictionary
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("couldn't decode dictionary: %w", err)
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
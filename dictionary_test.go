@@ -0,0 +1,138 @@
+package dynamixel
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAccessJSONRoundTrip(t *testing.T) {
+	for _, a := range []access{ro, rw, wo} {
+		b, err := json.Marshal(a)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", a, err)
+		}
+
+		var got access
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", b, err)
+		}
+		if got != a {
+			t.Errorf("round trip of %v via %s = %v", a, b, got)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`"bogus"`), new(access)); err == nil {
+		t.Error("Unmarshal(\"bogus\") = nil error, want one")
+	}
+}
+
+func TestDictionaryValidateRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		dict    Dictionary
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			dict:    Dictionary{Entries: []DictEntry{{Identifier: "a", Length: 1, Access: ro}}},
+			wantErr: "missing a name",
+		},
+		{
+			name: "duplicate identifier",
+			dict: Dictionary{
+				Name: "test",
+				Entries: []DictEntry{
+					{Identifier: "a", Address: 0, Length: 1, Access: ro},
+					{Identifier: "a", Address: 1, Length: 1, Access: ro},
+				},
+			},
+			wantErr: "duplicate identifier",
+		},
+		{
+			name: "invalid length",
+			dict: Dictionary{
+				Name:    "test",
+				Entries: []DictEntry{{Identifier: "a", Address: 0, Length: 3, Access: ro}},
+			},
+			wantErr: "invalid length",
+		},
+		{
+			name: "invalid access mode",
+			dict: Dictionary{
+				Name:    "test",
+				Entries: []DictEntry{{Identifier: "a", Address: 0, Length: 1, Access: access(99)}},
+			},
+			wantErr: "invalid access mode",
+		},
+		{
+			name: "overlapping entries",
+			dict: Dictionary{
+				Name: "test",
+				Entries: []DictEntry{
+					{Identifier: "a", Address: 0, Length: 2, Access: ro},
+					{Identifier: "b", Address: 1, Length: 1, Access: ro},
+				},
+			},
+			wantErr: "overlaps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dict.validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDictionaryValidateAccepts(t *testing.T) {
+	d := Dictionary{
+		Name: "test",
+		Entries: []DictEntry{
+			{Identifier: "a", Address: 0, Length: 2, Access: ro},
+			{Identifier: "b", Address: 2, Length: 1, Access: rw},
+		},
+	}
+	if err := d.validate(); err != nil {
+		t.Fatalf("validate() = %v, want nil", err)
+	}
+}
+
+func TestDictEntryEngineeringConversion(t *testing.T) {
+	e := DictEntry{Scale: 0.1, Offset: 2}
+	if got, want := e.toEngineering(50), 7.0; got != want {
+		t.Errorf("toEngineering(50) = %v, want %v", got, want)
+	}
+	if got, want := e.toRaw(7), 50; got != want {
+		t.Errorf("toRaw(7) = %v, want %v", got, want)
+	}
+}
+
+func TestDictionaryModelAngleRange(t *testing.T) {
+	d, err := LoadBundledDictionary("mx28")
+	if err != nil {
+		t.Fatalf("LoadBundledDictionary: %v", err)
+	}
+
+	m := NewDictionaryModel(d, protocol1{})
+	min, max := m.AngleRange()
+	if min != 0 || max < 359.9 || max > 360.1 {
+		t.Errorf("AngleRange() = (%v, %v), want approximately (0, 360)", min, max)
+	}
+}
+
+func TestLoadBundledDictionaries(t *testing.T) {
+	for _, name := range []string{"ax12a", "mx28", "xl320"} {
+		d, err := LoadBundledDictionary(name)
+		if err != nil {
+			t.Errorf("LoadBundledDictionary(%q): %v", name, err)
+			continue
+		}
+		if _, ok := d.Entry("goalPosition"); !ok {
+			t.Errorf("%q: dictionary has no goalPosition entry", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package dynamixel
+
+import "sync"
+
+// fakeNetworker is a minimal in-memory Networker for tests: it stores a flat
+// byte array per servo ID and serves ReadData/WriteData against it directly,
+// ignoring the wire protocol entirely - there's no real wire to frame bytes
+// for in a test.
+type fakeNetworker struct {
+	mu   sync.Mutex
+	mem  map[uint8][]byte
+	size int
+}
+
+func newFakeNetworker(size int) *fakeNetworker {
+	return &fakeNetworker{mem: make(map[uint8][]byte), size: size}
+}
+
+func (f *fakeNetworker) memFor(id uint8) []byte {
+	b, ok := f.mem[id]
+	if !ok {
+		b = make([]byte, f.size)
+		f.mem[id] = b
+	}
+	return b
+}
+
+func (f *fakeNetworker) Ping(id uint8, protocol Protocol) error { return nil }
+
+func (f *fakeNetworker) ReadData(id uint8, addr byte, length int, protocol Protocol) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b := f.memFor(id)
+	out := make([]byte, length)
+	copy(out, b[addr:int(addr)+length])
+	return out, nil
+}
+
+func (f *fakeNetworker) WriteData(id uint8, wait bool, protocol Protocol, params ...byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b := f.memFor(id)
+	copy(b[params[0]:], params[1:])
+	return nil
+}
+
+func (f *fakeNetworker) RegWriteData(id uint8, wait bool, protocol Protocol, params ...byte) error {
+	return f.WriteData(id, wait, protocol, params...)
+}
+
+func (f *fakeNetworker) Action(id uint8, protocol Protocol) error { return nil }
+
+func (f *fakeNetworker) SyncWrite(addr byte, values map[uint8][]byte, protocol Protocol) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, v := range values {
+		b := f.memFor(id)
+		copy(b[addr:], v)
+	}
+	return nil
+}
+
+func (f *fakeNetworker) BulkRead(reads []BulkReadSpec, protocol Protocol) (map[uint8][]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make(map[uint8][]byte, len(reads))
+	for _, r := range reads {
+		b := f.memFor(r.ID)
+		v := make([]byte, r.Length)
+		copy(v, b[r.Addr:int(r.Addr)+r.Length])
+		out[r.ID] = v
+	}
+	return out, nil
+}
+
+func (f *fakeNetworker) SetLogger(logger Logger) {}
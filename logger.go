@@ -0,0 +1,87 @@
+package dynamixel
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Level is a logging severity, lowest to highest.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Logger is a leveled logging sink, in the style of glog. Every
+// DynamixelServo has one (NopLogger by default), so logging is opt-in and
+// multi-servo callers can filter or route it per servo.
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+}
+
+// NopLogger discards every message. It's the default Logger.
+type NopLogger struct{}
+
+func (NopLogger) Debugf(string, ...interface{}) {}
+func (NopLogger) Infof(string, ...interface{})  {}
+func (NopLogger) Warnf(string, ...interface{})  {}
+func (NopLogger) Errorf(string, ...interface{}) {}
+
+// StdLogger writes level-prefixed lines to an io.Writer, dropping anything
+// below its configured level.
+type StdLogger struct {
+	w     io.Writer
+	level Level
+	mu    sync.Mutex
+}
+
+// NewStdLogger returns a StdLogger that writes to w, filtering out messages
+// below level.
+func NewStdLogger(w io.Writer, level Level) *StdLogger {
+	return &StdLogger{w: w, level: level}
+}
+
+func (l *StdLogger) logf(level Level, prefix, format string, v ...interface{}) {
+	if level < l.level {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, prefix+" "+format+"\n", v...)
+}
+
+func (l *StdLogger) Debugf(format string, v ...interface{}) { l.logf(LevelDebug, "[DEBUG]", format, v...) }
+func (l *StdLogger) Infof(format string, v ...interface{})  { l.logf(LevelInfo, "[INFO]", format, v...) }
+func (l *StdLogger) Warnf(format string, v ...interface{})  { l.logf(LevelWarn, "[WARN]", format, v...) }
+func (l *StdLogger) Errorf(format string, v ...interface{}) { l.logf(LevelError, "[ERROR]", format, v...) }
+
+// TestLogger records every message it receives, rather than writing it
+// anywhere, so tests can assert on log output.
+type TestLogger struct {
+	mu       sync.Mutex
+	Messages []string
+}
+
+// NewTestLogger returns an empty TestLogger.
+func NewTestLogger() *TestLogger {
+	return &TestLogger{}
+}
+
+func (l *TestLogger) record(level, format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Messages = append(l.Messages, fmt.Sprintf("["+level+"] "+format, v...))
+}
+
+func (l *TestLogger) Debugf(format string, v ...interface{}) { l.record("DEBUG", format, v...) }
+func (l *TestLogger) Infof(format string, v ...interface{})  { l.record("INFO", format, v...) }
+func (l *TestLogger) Warnf(format string, v ...interface{})  { l.record("WARN", format, v...) }
+func (l *TestLogger) Errorf(format string, v ...interface{}) { l.record("ERROR", format, v...) }
@@ -0,0 +1,39 @@
+package dynamixel
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStdLoggerFiltersBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(&buf, LevelWarn)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Warnf("warn %d", 3)
+	logger.Errorf("error %d", 4)
+
+	out := buf.String()
+	for _, want := range []string{"warn 3", "error 4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+	for _, notWant := range []string{"debug 1", "info 2"} {
+		if strings.Contains(out, notWant) {
+			t.Errorf("output contains filtered message %q: %s", notWant, out)
+		}
+	}
+}
+
+func TestNopLoggerDiscardsEverything(t *testing.T) {
+	// Mostly here so NopLogger keeps satisfying Logger; there's nothing to
+	// assert on beyond "doesn't panic".
+	var l Logger = NopLogger{}
+	l.Debugf("%d", 1)
+	l.Infof("%d", 2)
+	l.Warnf("%d", 3)
+	l.Errorf("%d", 4)
+}
@@ -0,0 +1,158 @@
+package dynamixel
+
+import "fmt"
+
+// Model describes the characteristics of a specific servo model: its control
+// table layout, position/angle ranges, and the wire protocol it speaks. A
+// DynamixelServo is driven by a Model so that the same high-level API works
+// across AX, MX, XL and compatible third-party servos.
+type Model interface {
+	// Name returns a human-readable model name, e.g. "AX-12A".
+	Name() string
+
+	// ControlTable returns this model's register map.
+	ControlTable() map[registerID]*Register
+
+	// PositionRange returns the minimum and maximum raw position values.
+	PositionRange() (min, max uint16)
+
+	// AngleRange returns the minimum and maximum angle, in degrees, that
+	// PositionRange maps onto.
+	AngleRange() (min, max float64)
+
+	// Protocol returns the adapter used to frame instructions and parse
+	// status packets for this model.
+	Protocol() Protocol
+}
+
+// Protocol builds raw instruction packets and parses raw status packets. Each
+// servo family speaks one of these; the transport (Networker) just moves the
+// resulting bytes across the wire.
+type Protocol interface {
+	// EncodeInstruction builds a raw instruction packet addressed to id.
+	EncodeInstruction(id uint8, instruction byte, params ...byte) []byte
+
+	// DecodeStatus parses a raw status packet, returning its parameters or
+	// the error it reported.
+	DecodeStatus(packet []byte) (params []byte, err error)
+}
+
+// axModel implements Model for the AX series (AX-12, AX-12A, AX-18A, ...),
+// which speaks Dynamixel protocol 1.
+type axModel struct{}
+
+func (axModel) Name() string                          { return "AX" }
+func (axModel) ControlTable() map[registerID]*Register { return axControlTable }
+func (axModel) PositionRange() (min, max uint16)       { return 0, 1023 }
+func (axModel) AngleRange() (min, max float64)         { return 0, 300 }
+func (axModel) Protocol() Protocol                     { return protocol1{} }
+
+// mxModel implements Model for the MX series (MX-28, MX-64, MX-106), which
+// shares the AX control table layout but has 12-bit resolution and a 360
+// degree range. It speaks Dynamixel protocol 1, like the AX series.
+type mxModel struct{}
+
+func (mxModel) Name() string                          { return "MX" }
+func (mxModel) ControlTable() map[registerID]*Register { return mxControlTable }
+func (mxModel) PositionRange() (min, max uint16)       { return 0, 4095 }
+func (mxModel) AngleRange() (min, max float64)         { return 0, 360 }
+func (mxModel) Protocol() Protocol                     { return protocol1{} }
+
+// mxControlTable is axControlTable with the position registers widened to
+// MX's 12-bit range.
+var mxControlTable = func() map[registerID]*Register {
+	table := make(map[registerID]*Register, len(axControlTable))
+	for id, r := range axControlTable {
+		cp := *r
+		table[id] = &cp
+	}
+	for _, id := range []registerID{cwAngleLimit, ccwAngleLimit, goalPosition} {
+		table[id].max = 4095
+	}
+	return table
+}()
+
+// xlModel implements Model for the X series (XL-320 and friends), which
+// speaks Dynamixel protocol 2 (0xFF 0xFF 0xFD header, CRC-16/IBM). Its
+// control table shares the AX/MX register names but not their addresses;
+// see xlControlTable.
+type xlModel struct{}
+
+func (xlModel) Name() string                          { return "XL-320" }
+func (xlModel) ControlTable() map[registerID]*Register { return xlControlTable }
+func (xlModel) PositionRange() (min, max uint16)       { return 0, 1023 }
+func (xlModel) AngleRange() (min, max float64)         { return 0, 300 }
+func (xlModel) Protocol() Protocol                     { return protocol2{} }
+
+// lxModel implements Model for the Lobot LX-15D, a third-party serial
+// half-duplex servo with its own 0x55 0x55 header and checksum framing.
+//
+// The LX-15D doesn't speak a Dynamixel-style "read/write N bytes at this
+// address" register protocol at all - its commands (e.g. SERVO_MOVE_TIME_WRITE)
+// carry their own, per-command parameter layouts, which this package's
+// registerID-based control table can't represent. Rather than borrow the AX
+// table and silently frame the wrong bytes, ControlTable returns an empty
+// table: every register lookup fails with "register not present", the same
+// error path used for any register a model genuinely doesn't have, until
+// LX-15D gets a real command-based driver instead of a control table.
+type lxModel struct{}
+
+func (lxModel) Name() string                          { return "LX-15D" }
+func (lxModel) ControlTable() map[registerID]*Register { return map[registerID]*Register{} }
+func (lxModel) PositionRange() (min, max uint16)       { return 0, 1000 }
+func (lxModel) AngleRange() (min, max float64)         { return 0, 240 }
+func (lxModel) Protocol() Protocol                     { return lxProtocol{} }
+
+// Known model numbers, as reported by the modelNumber register. Used by
+// DetectModel to pick a Model without the caller having to know the servo's
+// family in advance.
+const (
+	modelNumAX12A = 12
+	modelNumMX28  = 29
+	modelNumXL320 = 350
+)
+
+// AX12A, MX28 and XL320 are ready-to-use Models for their respective servo
+// families. LX15D covers the Lobot LX-15D, but its ControlTable is
+// intentionally empty until it gets a real command-based driver - see
+// lxModel's doc comment.
+var (
+	AX12A = axModel{}
+	MX28  = mxModel{}
+	XL320 = xlModel{}
+	LX15D = lxModel{}
+)
+
+// DetectModel reads the modelNumber register of the servo identified by id on
+// network, and returns the Model that matches it. Since the modelNumber
+// register sits at the very start of every known control table (address 0,
+// 2 bytes), this works before the caller knows which Model to use - but the
+// caller must still know (or guess) the servo's wire protocol to frame the
+// read with; protocol1 is the right guess for a fresh AX/MX bus.
+//
+// LX-15D can't be detected this way: it doesn't speak the "read N bytes at
+// an address" register protocol at all (see lxModel's doc comment), so
+// there's no modelNumber register to read in the first place. Callers
+// talking to an LX-15D must select LX15D explicitly.
+func DetectModel(network Networker, id uint8, protocol Protocol) (Model, error) {
+	b, err := network.ReadData(id, 0, 2, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read model number: %w", err)
+	}
+
+	num, err := bytesToInt(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch num {
+	case modelNumAX12A:
+		return AX12A, nil
+	case modelNumMX28:
+		return MX28, nil
+	case modelNumXL320:
+		return XL320, nil
+	default:
+		return nil, fmt.Errorf("unrecognised model number: %d", num)
+	}
+}
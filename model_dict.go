@@ -0,0 +1,80 @@
+package dynamixel
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed dictionaries/*.json
+var bundledDictionaries embed.FS
+
+// DictionaryModel is a Model whose control table, position range and angle
+// range all come from a loaded Dictionary, rather than being compiled in.
+// This is how newer or third-party models (or field updates to existing
+// ones) get supported without a Go code change: ship a dictionary file.
+type DictionaryModel struct {
+	dict     *Dictionary
+	protocol Protocol
+}
+
+// NewDictionaryModel builds a Model from dict, using protocol to frame
+// instructions on the wire.
+func NewDictionaryModel(dict *Dictionary, protocol Protocol) *DictionaryModel {
+	return &DictionaryModel{dict: dict, protocol: protocol}
+}
+
+func (m *DictionaryModel) Name() string { return m.dict.Name }
+
+func (m *DictionaryModel) Protocol() Protocol { return m.protocol }
+
+// Dictionary returns the model's backing Dictionary, so callers can look up
+// entries (and thus units/scale/offset) that ControlTable can't express.
+func (m *DictionaryModel) Dictionary() *Dictionary { return m.dict }
+
+// ControlTable builds a registerID-keyed table out of the dictionary's
+// entries, for the benefit of DynamixelServo's built-in getters/setters
+// (ModelNumber, GoalPosition, etc). Entries whose identifier isn't one of
+// the names in registerNames are still readable/writable through
+// Servo.ReadReg/WriteReg, just not through the named Go methods.
+func (m *DictionaryModel) ControlTable() map[registerID]*Register {
+	table := make(map[registerID]*Register, len(m.dict.Entries))
+	for _, e := range m.dict.Entries {
+		if id, ok := registerIDByName[e.Identifier]; ok {
+			reg := e.Register()
+			table[id] = &reg
+		}
+	}
+	return table
+}
+
+func (m *DictionaryModel) PositionRange() (min, max uint16) {
+	if e, ok := m.dict.Entry("goalPosition"); ok {
+		return uint16(e.Min), uint16(e.Max)
+	}
+	return 0, 0
+}
+
+func (m *DictionaryModel) AngleRange() (min, max float64) {
+	// The dictionary format doesn't carry an angle range directly; it's
+	// derived from goalPosition's own scale/offset, the same conversion
+	// DictEntry.toEngineering uses everywhere else, so any dictionary that
+	// sets them correctly gets the right range regardless of its raw
+	// position resolution.
+	e, ok := m.dict.Entry("goalPosition")
+	if !ok {
+		return 0, 0
+	}
+	return e.toEngineering(e.Min), e.toEngineering(e.Max)
+}
+
+// LoadBundledDictionary loads one of the dictionaries shipped under
+// dictionaries/ (currently "ax12a", "mx28" and "xl320").
+func LoadBundledDictionary(name string) (*Dictionary, error) {
+	f, err := bundledDictionaries.Open("dictionaries/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no bundled dictionary named %q: %w", name, err)
+	}
+	defer f.Close()
+
+	return LoadDictionary(f)
+}
@@ -0,0 +1,155 @@
+package dynamixel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// rpmPerSpeedUnit is the AX/MX MovingSpeed unit: each step is 0.111 rpm.
+const rpmPerSpeedUnit = 0.111
+
+// MoveOverDuration moves the servo to angle (in degrees), taking
+// approximately d to get there. AX-class servos have no native notion of
+// duration, so this computes the MovingSpeed required to cover the distance
+// in d, then writes MovingSpeed and GoalPosition together.
+func (servo *DynamixelServo) MoveOverDuration(angle float64, d time.Duration) error {
+	servo.debugf("MoveOverDuration(%.2f, %s)", angle, d)
+	return servo.moveOverDuration(angle, d, false)
+}
+
+// QueueMove behaves like MoveOverDuration, except the write is buffered on
+// the servo (via REG_WRITE) rather than applied immediately. Call
+// StartQueuedMove to trigger it - typically after queueing moves on several
+// servos, so they all start at once.
+func (servo *DynamixelServo) QueueMove(angle float64, d time.Duration) error {
+	servo.debugf("QueueMove(%.2f, %s)", angle, d)
+	return servo.moveOverDuration(angle, d, true)
+}
+
+// StartQueuedMove triggers every move previously buffered with QueueMove,
+// across every servo on the bus, via a broadcast ACTION instruction. Servos
+// that don't have a move queued simply ignore it.
+func (servo *DynamixelServo) StartQueuedMove() error {
+	servo.debugf("StartQueuedMove()")
+	return servo.Network.Action(BroadcastID, servo.Protocol())
+}
+
+// StopMove stops the servo where it currently is. AX-class servos have no
+// native "stop" instruction, so this reads the present position and writes
+// it back as the goal position.
+func (servo *DynamixelServo) StopMove() error {
+	servo.debugf("StopMove()")
+
+	pos, err := servo.PresentPosition()
+	if err != nil {
+		return err
+	}
+	return servo.SetGoalPosition(pos)
+}
+
+// AwaitStop blocks until the servo reports that it's no longer moving, or
+// ctx is done, whichever happens first.
+func (servo *DynamixelServo) AwaitStop(ctx context.Context) error {
+	const pollInterval = 50 * time.Millisecond
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			m, err := servo.Moving()
+			if err != nil {
+				return err
+			}
+			if m == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// moveOverDuration computes the goal position and moving speed needed to
+// reach angle in roughly d, then writes both registers in a single
+// instruction - buffered (REG_WRITE) if queue is true, applied immediately
+// (WRITE_DATA) otherwise.
+func (servo *DynamixelServo) moveOverDuration(angle float64, d time.Duration, queue bool) error {
+	speed, pos, err := servo.speedAndPosFor(angle, d)
+	if err != nil {
+		return err
+	}
+
+	posReg, err := servo.reg(goalPosition)
+	if err != nil {
+		return err
+	}
+
+	speedReg, err := servo.reg(movingSpeed)
+	if err != nil {
+		return err
+	}
+
+	// GoalPosition and MovingSpeed must be adjacent for a single write to
+	// cover both.
+	if speedReg.address != posReg.address+byte(posReg.length) {
+		return fmt.Errorf("movingSpeed and goalPosition aren't adjacent on %s; can't write them together", servo.model.Name())
+	}
+
+	params := []byte{posReg.address, low(pos), high(pos), low(speed), high(speed)}
+	wait := servo.statusReturnLevel == 2
+
+	if queue {
+		return servo.Network.RegWriteData(servo.Ident, wait, servo.Protocol(), params...)
+	}
+
+	if err := servo.Network.WriteData(servo.Ident, wait, servo.Protocol(), params...); err != nil {
+		return err
+	}
+
+	servo.cache[posReg.address] = low(pos)
+	servo.cache[posReg.address+1] = high(pos)
+	servo.cache[speedReg.address] = low(speed)
+	servo.cache[speedReg.address+1] = high(speed)
+	return nil
+}
+
+// speedAndPosFor computes the raw goal position for angle, and the raw
+// MovingSpeed needed to cover the distance from the servo's current angle in
+// approximately d, clamped to the model's maximum speed.
+func (servo *DynamixelServo) speedAndPosFor(angle float64, d time.Duration) (speed, pos int, err error) {
+	if d <= 0 {
+		return 0, 0, errors.New("duration must be positive")
+	}
+
+	curAngle, err := servo.Angle()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	target := normalizeAngle(angle)
+	pos = servo.angleToPos(target)
+
+	degPerSec := math.Abs(target-curAngle) / d.Seconds()
+	rpm := degPerSec / 6 // 360 deg/rev, 60 sec/min
+	speed = int(math.Round(rpm / rpmPerSpeedUnit))
+
+	speedReg, err := servo.reg(movingSpeed)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch {
+	case speed > speedReg.max:
+		speed = speedReg.max
+	case speed < speedReg.min:
+		speed = speedReg.min
+	}
+
+	return speed, pos, nil
+}
@@ -0,0 +1,45 @@
+package dynamixel
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpeedAndPosForClampsToMovingSpeedRange(t *testing.T) {
+	servo := newTestServo(t)
+
+	// A huge angle change over a tiny duration demands far more speed than
+	// movingSpeed's max (1023 for AX12A) allows.
+	speed, _, err := servo.speedAndPosFor(150, time.Microsecond)
+	if err != nil {
+		t.Fatalf("speedAndPosFor: %v", err)
+	}
+
+	reg, err := servo.reg(movingSpeed)
+	if err != nil {
+		t.Fatalf("reg(movingSpeed): %v", err)
+	}
+	if speed != reg.max {
+		t.Errorf("speed = %d, want clamped to max %d", speed, reg.max)
+	}
+}
+
+func TestSpeedAndPosForRejectsNonPositiveDuration(t *testing.T) {
+	servo := newTestServo(t)
+
+	if _, _, err := servo.speedAndPosFor(10, 0); err == nil {
+		t.Error("speedAndPosFor(d=0) = nil error, want one")
+	}
+}
+
+func TestSpeedAndPosForComputesPosition(t *testing.T) {
+	servo := newTestServo(t)
+
+	_, pos, err := servo.speedAndPosFor(10, time.Second)
+	if err != nil {
+		t.Fatalf("speedAndPosFor: %v", err)
+	}
+	if want := servo.angleToPos(10); pos != want {
+		t.Errorf("pos = %d, want %d", pos, want)
+	}
+}
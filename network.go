@@ -0,0 +1,60 @@
+package dynamixel
+
+// Networker is implemented by anything capable of sending raw Dynamixel
+// packets over the wire and returning the response bytes. It's a generic
+// byte transport: every method takes the Protocol to frame its instruction
+// with, so a single Networker (one serial port) can carry servos of
+// different protocols (AX/MX's protocol 1 alongside XL-320's protocol 2, or
+// a Lobot LX servo) without knowing about any of them itself. Callers get
+// the right Protocol from the servo's Model, via DynamixelServo.Protocol()
+// or Bus.Protocol.
+type Networker interface {
+	// Ping sends the PING instruction to id, returning nil if the servo
+	// responds.
+	Ping(id uint8, protocol Protocol) error
+
+	// ReadData reads length bytes starting at addr from id's control table.
+	ReadData(id uint8, addr byte, length int, protocol Protocol) ([]byte, error)
+
+	// WriteData sends the WRITE_DATA instruction to id. params[0] is the
+	// address to write to, and the remaining bytes are the value. If wait is
+	// true, the call blocks for id's status packet.
+	WriteData(id uint8, wait bool, protocol Protocol, params ...byte) error
+
+	// RegWriteData is identical to WriteData, except the servo buffers the
+	// write instead of applying it immediately; it's applied on the next
+	// Action.
+	RegWriteData(id uint8, wait bool, protocol Protocol, params ...byte) error
+
+	// Action triggers any RegWriteData instructions buffered on id. Pass the
+	// broadcast ID to trigger every servo on the bus at once, so several
+	// servos can start a timed move in lockstep.
+	Action(id uint8, protocol Protocol) error
+
+	// SyncWrite sends the SYNC_WRITE instruction, writing the byte slice in
+	// values[id] to addr on every listed servo ID in a single packet. Every
+	// value must be the same length. Every servo addressed must share
+	// protocol.
+	SyncWrite(addr byte, values map[uint8][]byte, protocol Protocol) error
+
+	// BulkRead sends the BULK_READ instruction, reading every entry in reads
+	// in a single round trip. Every servo addressed must share protocol.
+	BulkRead(reads []BulkReadSpec, protocol Protocol) (map[uint8][]byte, error)
+
+	// SetLogger installs the Logger the Networker should use for its own
+	// packet-level logging (encoded packets, round-trip latency, retries).
+	SetLogger(logger Logger)
+}
+
+// BulkReadSpec describes one servo's share of a BulkRead: the register
+// address and length to read from it.
+type BulkReadSpec struct {
+	ID     uint8
+	Addr   byte
+	Length int
+}
+
+// BroadcastID is the reserved servo ID that every Dynamixel servo on a bus
+// responds to, regardless of its own ID. It's used to trigger a buffered
+// Action on many servos at once.
+const BroadcastID uint8 = 0xFE
@@ -0,0 +1,120 @@
+package dynamixel
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errInvalidPacket is returned when a status packet doesn't start with the
+// header its protocol expects.
+var errInvalidPacket = errors.New("invalid status packet")
+
+// statusError represents a non-zero error byte in a status packet.
+type statusError byte
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("servo reported status error: 0x%02X", byte(e))
+}
+
+// protocol1 implements Protocol for Dynamixel protocol 1, used by the AX and
+// MX series: 0xFF 0xFF header, a trailing one-byte checksum.
+type protocol1 struct{}
+
+func (protocol1) EncodeInstruction(id uint8, instruction byte, params ...byte) []byte {
+	length := byte(len(params) + 2)
+	packet := append([]byte{0xFF, 0xFF, id, length, instruction}, params...)
+	packet = append(packet, checksum(packet[2:]))
+	return packet
+}
+
+func (protocol1) DecodeStatus(packet []byte) ([]byte, error) {
+	if len(packet) < 6 || packet[0] != 0xFF || packet[1] != 0xFF {
+		return nil, errInvalidPacket
+	}
+
+	length := int(packet[3])
+	errByte := packet[4]
+	params := packet[5 : 5+length-2]
+
+	if errByte != 0 {
+		return params, statusError(errByte)
+	}
+	return params, nil
+}
+
+// checksum is the Dynamixel protocol 1 checksum: the lower byte of the
+// bitwise NOT of the sum of every byte from ID to the last parameter.
+func checksum(b []byte) byte {
+	var sum int
+	for _, v := range b {
+		sum += int(v)
+	}
+	return ^byte(sum)
+}
+
+// protocol2 implements Protocol for Dynamixel protocol 2, used by the X
+// series (including XL-320): 0xFF 0xFF 0xFD 0x00 header, CRC-16/IBM trailer.
+type protocol2 struct{}
+
+func (protocol2) EncodeInstruction(id uint8, instruction byte, params ...byte) []byte {
+	length := uint16(len(params) + 3)
+	packet := []byte{0xFF, 0xFF, 0xFD, 0x00, id, low(int(length)), high(int(length)), instruction}
+	packet = append(packet, params...)
+
+	crc := crc16(packet)
+	packet = append(packet, low(int(crc)), high(int(crc)))
+	return packet
+}
+
+func (protocol2) DecodeStatus(packet []byte) ([]byte, error) {
+	if len(packet) < 11 || packet[0] != 0xFF || packet[1] != 0xFF || packet[2] != 0xFD {
+		return nil, errInvalidPacket
+	}
+
+	length := int(packet[5]) | int(packet[6])<<8
+	errByte := packet[8]
+	params := packet[9 : 9+length-4]
+
+	if errByte != 0 {
+		return params, statusError(errByte)
+	}
+	return params, nil
+}
+
+// crc16 computes the CRC-16/IBM (ANSI) checksum used by Dynamixel protocol 2.
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = (crc >> 1) ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// lxProtocol implements Protocol for the Lobot LX-15D and compatible
+// half-duplex serial servos: 0x55 0x55 header, a trailing one-byte checksum
+// (same algorithm as protocol1, just with a different header).
+type lxProtocol struct{}
+
+func (lxProtocol) EncodeInstruction(id uint8, instruction byte, params ...byte) []byte {
+	length := byte(len(params) + 2)
+	packet := append([]byte{0x55, 0x55, id, length, instruction}, params...)
+	packet = append(packet, checksum(packet[2:]))
+	return packet
+}
+
+func (lxProtocol) DecodeStatus(packet []byte) ([]byte, error) {
+	if len(packet) < 6 || packet[0] != 0x55 || packet[1] != 0x55 {
+		return nil, errInvalidPacket
+	}
+
+	length := int(packet[3])
+	params := packet[5 : 5+length-2]
+	return params, nil
+}
@@ -0,0 +1,76 @@
+package dynamixel
+
+import "testing"
+
+func TestChecksum(t *testing.T) {
+	// AX PING to ID 1 (dxl_ax_actuator.htm's worked example): 0xFF 0xFF 0x01
+	// 0x02 0x01, trailing checksum 0xFB.
+	if got, want := checksum([]byte{0x01, 0x02, 0x01}), byte(0xFB); got != want {
+		t.Errorf("checksum() = 0x%02X, want 0x%02X", got, want)
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	if got := crc16(nil); got != 0 {
+		t.Errorf("crc16(nil) = %d, want 0", got)
+	}
+
+	a := crc16([]byte{0xFF, 0xFF, 0xFD, 0x00, 0x01, 0x03, 0x00, 0x01})
+	b := crc16([]byte{0xFF, 0xFF, 0xFD, 0x00, 0x02, 0x03, 0x00, 0x01})
+	if a == b {
+		t.Errorf("crc16 collided across packets differing only in ID: 0x%04X", a)
+	}
+}
+
+func TestProtocol1DecodeStatus(t *testing.T) {
+	packet := append([]byte{0xFF, 0xFF, 0x01, 0x04, 0x00, 0xAB, 0xCD}, checksum([]byte{0x01, 0x04, 0x00, 0xAB, 0xCD}))
+
+	params, err := (protocol1{}).DecodeStatus(packet)
+	if err != nil {
+		t.Fatalf("DecodeStatus: %v", err)
+	}
+	if got, want := params, []byte{0xAB, 0xCD}; string(got) != string(want) {
+		t.Errorf("params = %v, want %v", got, want)
+	}
+}
+
+func TestProtocol1DecodeStatusError(t *testing.T) {
+	packet := append([]byte{0xFF, 0xFF, 0x01, 0x02, 0x10}, checksum([]byte{0x01, 0x02, 0x10}))
+
+	_, err := (protocol1{}).DecodeStatus(packet)
+	if _, ok := err.(statusError); !ok {
+		t.Errorf("DecodeStatus() error = %v (%T), want a statusError", err, err)
+	}
+}
+
+func TestProtocol1DecodeStatusRejectsBadHeader(t *testing.T) {
+	_, err := (protocol1{}).DecodeStatus([]byte{0x00, 0x00, 0x01, 0x02, 0x00, 0x00})
+	if err != errInvalidPacket {
+		t.Errorf("DecodeStatus() error = %v, want errInvalidPacket", err)
+	}
+}
+
+func TestProtocol2EncodeDecodeRoundTrip(t *testing.T) {
+	// protocol2 status and instruction packets share the same header/trailer
+	// shape, but a status packet carries an error byte right after the
+	// instruction byte, where an instruction packet's first parameter sits -
+	// so an instruction packet whose first parameter byte happens to be zero
+	// (no error) decodes back out to its remaining parameters.
+	params := []byte{0x00, 0x2A, 0x01}
+	packet := (protocol2{}).EncodeInstruction(1, 0x03, params...)
+
+	got, err := (protocol2{}).DecodeStatus(packet)
+	if err != nil {
+		t.Fatalf("DecodeStatus: %v", err)
+	}
+	if string(got) != string(params[1:]) {
+		t.Errorf("params = %v, want %v", got, params[1:])
+	}
+}
+
+func TestProtocol2DecodeStatusRejectsBadHeader(t *testing.T) {
+	_, err := (protocol2{}).DecodeStatus(make([]byte, 11))
+	if err != errInvalidPacket {
+		t.Errorf("DecodeStatus() error = %v, want errInvalidPacket", err)
+	}
+}
@@ -0,0 +1,253 @@
+package dynamixel
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sample is one timestamped row captured by a Recorder: the raw register
+// values read from every servo at that tick, keyed by servo ID and
+// register.
+type Sample struct {
+	At     time.Time
+	Values map[uint8]map[registerID]int
+}
+
+// flat flattens a Sample into "servoID.registerName" -> value, for the
+// CSV/JSONL exporters.
+func (s Sample) flat() map[string]int {
+	out := make(map[string]int)
+	for id, regs := range s.Values {
+		for reg, v := range regs {
+			name := registerNames[reg]
+			if name == "" {
+				name = fmt.Sprintf("reg%d", reg)
+			}
+			out[fmt.Sprintf("%d.%s", id, name)] = v
+		}
+	}
+	return out
+}
+
+// Recorder samples a fixed set of registers at a fixed period into a
+// preallocated ring buffer, in the spirit of IngeniaLink's disturbance
+// capture. It's how PID/compliance tuning, move plotting and fault
+// reproduction happen without hand-rolling a polling loop.
+type Recorder struct {
+	sample func() (map[uint8]map[registerID]int, error)
+	period time.Duration
+
+	mu      sync.Mutex
+	buf     []Sample
+	next    int
+	filled  bool
+	running bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newRecorder(samples int, period time.Duration, sample func() (map[uint8]map[registerID]int, error)) (*Recorder, error) {
+	if samples <= 0 {
+		return nil, fmt.Errorf("samples must be positive: %d", samples)
+	}
+
+	return &Recorder{
+		sample: sample,
+		period: period,
+		buf:    make([]Sample, samples),
+	}, nil
+}
+
+// NewRecorder returns a Recorder that samples regs from servo at period,
+// keeping the most recent samples readings in a ring buffer. samples must be
+// positive.
+func (servo *DynamixelServo) NewRecorder(regs []registerID, samples int, period time.Duration) (*Recorder, error) {
+	return newRecorder(samples, period, func() (map[uint8]map[registerID]int, error) {
+		values := make(map[registerID]int, len(regs))
+		for _, id := range regs {
+			v, err := servo.get(id)
+			if err != nil {
+				return nil, err
+			}
+			values[id] = v
+		}
+		return map[uint8]map[registerID]int{servo.Ident: values}, nil
+	})
+}
+
+// NewRecorder returns a Recorder that samples reg from every servo in ids
+// using a single BULK_READ per tick, so a full multi-servo arm can be
+// sampled at hundreds of Hz instead of one ReadData round trip per servo.
+// samples must be positive.
+func (bus *Bus) NewRecorder(id registerID, reg Register, ids []uint8, samples int, period time.Duration) (*Recorder, error) {
+	return newRecorder(samples, period, func() (map[uint8]map[registerID]int, error) {
+		raw, err := bus.BulkRead(reg, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[uint8]map[registerID]int, len(raw))
+		for servoID, v := range raw {
+			values[servoID] = map[registerID]int{id: v}
+		}
+		return values, nil
+	})
+}
+
+// Start begins sampling in a background goroutine. It's a no-op if the
+// Recorder is already running.
+func (r *Recorder) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = true
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	r.mu.Unlock()
+
+	go r.loop()
+}
+
+func (r *Recorder) loop() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			values, err := r.sample()
+			if err != nil {
+				// A single failed tick (e.g. a transient bus error)
+				// shouldn't take the whole recorder down.
+				continue
+			}
+			r.record(Sample{At: time.Now(), Values: values})
+		}
+	}
+}
+
+func (r *Recorder) record(s Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf[r.next] = s
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.filled = true
+	}
+}
+
+// Stop halts sampling and waits for the background goroutine to exit. It's a
+// no-op if the Recorder isn't running.
+func (r *Recorder) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	close(r.stop)
+	done := r.done
+	r.mu.Unlock()
+
+	<-done
+}
+
+// Snapshot returns every sample currently held, oldest first.
+func (r *Recorder) Snapshot() []Sample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.filled {
+		out := make([]Sample, r.next)
+		copy(out, r.buf[:r.next])
+		return out
+	}
+
+	out := make([]Sample, len(r.buf))
+	n := copy(out, r.buf[r.next:])
+	copy(out[n:], r.buf[:r.next])
+	return out
+}
+
+// columnsOf returns the sorted union of every "servoID.registerName" column
+// across samples, so CSV/JSONL output has a stable column order.
+func columnsOf(samples []Sample) []string {
+	seen := make(map[string]bool)
+	var cols []string
+
+	for _, s := range samples {
+		for k := range s.flat() {
+			if !seen[k] {
+				seen[k] = true
+				cols = append(cols, k)
+			}
+		}
+	}
+
+	sort.Strings(cols)
+	return cols
+}
+
+// WriteCSV writes every held sample to w as CSV, one row per sample with a
+// "timestamp" column followed by one column per servoID.registerName.
+func (r *Recorder) WriteCSV(w io.Writer) error {
+	samples := r.Snapshot()
+	cols := columnsOf(samples)
+
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write(append([]string{"timestamp"}, cols...)); err != nil {
+		return err
+	}
+
+	for _, s := range samples {
+		flat := s.flat()
+		row := make([]string, len(cols)+1)
+		row[0] = s.At.Format(time.RFC3339Nano)
+
+		for i, col := range cols {
+			if v, ok := flat[col]; ok {
+				row[i+1] = strconv.Itoa(v)
+			}
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return cw.Error()
+}
+
+// WriteJSONL writes every held sample to w as newline-delimited JSON, one
+// object per sample.
+func (r *Recorder) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for _, s := range r.Snapshot() {
+		row := struct {
+			At     time.Time      `json:"at"`
+			Values map[string]int `json:"values"`
+		}{At: s.At, Values: s.flat()}
+
+		if err := enc.Encode(&row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
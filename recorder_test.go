@@ -0,0 +1,47 @@
+package dynamixel
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRecorderRejectsNonPositiveSamples(t *testing.T) {
+	net := newFakeNetworker(tableSizeOf(AX12A.ControlTable()))
+	servo, err := NewServo(net, AX12A, 1)
+	if err != nil {
+		t.Fatalf("NewServo: %v", err)
+	}
+
+	for _, samples := range []int{0, -1} {
+		if _, err := servo.NewRecorder([]registerID{presentPosition}, samples, time.Millisecond); err == nil {
+			t.Errorf("NewRecorder(samples=%d): expected an error, got nil", samples)
+		}
+	}
+}
+
+func TestRecorderRingBufferWraps(t *testing.T) {
+	r, err := newRecorder(3, time.Millisecond, func() (map[uint8]map[registerID]int, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("newRecorder: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		r.record(Sample{Values: map[uint8]map[registerID]int{0: {presentPosition: i}}})
+	}
+
+	snap := r.Snapshot()
+	if len(snap) != 3 {
+		t.Fatalf("Snapshot(): got %d samples, want 3", len(snap))
+	}
+
+	// The buffer only holds 3 slots, so it should contain the 3 most recent
+	// samples (2, 3, 4), oldest first.
+	for i, s := range snap {
+		want := i + 2
+		if got := s.Values[0][presentPosition]; got != want {
+			t.Errorf("snap[%d] = %d, want %d", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package dynamixel
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// access describes whether a register can be read, written, or both.
+type access int
+
+const (
+	ro access = iota
+	rw
+	wo
+)
+
+// String returns the access mode's on-disk form ("ro"/"rw"/"wo").
+func (a access) String() string {
+	switch a {
+	case ro:
+		return "ro"
+	case rw:
+		return "rw"
+	case wo:
+		return "wo"
+	default:
+		return fmt.Sprintf("access(%d)", int(a))
+	}
+}
+
+// MarshalJSON encodes access as "ro"/"rw"/"wo", rather than its underlying
+// int, so Dictionary files aren't coupled to this type's iota ordering.
+func (a access) MarshalJSON() ([]byte, error) {
+	switch a {
+	case ro, rw, wo:
+		return json.Marshal(a.String())
+	default:
+		return nil, fmt.Errorf("invalid access mode: %d", int(a))
+	}
+}
+
+// UnmarshalJSON decodes "ro"/"rw"/"wo" into access.
+func (a *access) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case "ro":
+		*a = ro
+	case "rw":
+		*a = rw
+	case "wo":
+		*a = wo
+	default:
+		return fmt.Errorf("invalid access mode: %q", s)
+	}
+	return nil
+}
+
+// registerID identifies a logical register in a model's control table,
+// independent of its address, which varies between models.
+type registerID int
+
+const (
+	modelNumber registerID = iota
+	firmwareVersion
+	servoID
+	baudRate
+	returnDelayTime
+	cwAngleLimit
+	ccwAngleLimit
+	highestLimitTemperature
+	lowestLimitVoltage
+	highestLimitVoltage
+	maxTorque
+	statusReturnLevel
+	alarmLed
+	alarmShutdown
+	torqueEnable
+	led
+	cwComplianceMargin
+	ccwComplianceMargin
+	cwComplianceSlope
+	ccwComplianceSlope
+	goalPosition
+	movingSpeed
+	torqueLimit
+	presentPosition
+	presentSpeed
+	presentLoad
+	presentVoltage
+	presentTemperature
+	registered
+	moving
+	lock
+	punch
+)
+
+// Register describes a single entry in a servo's control table.
+type Register struct {
+	address   byte
+	length    int
+	access    access
+	cacheable bool
+	min, max  int
+}
+
+// axControlTable is the AX-12A control table (dxl_ax_actuator.htm). It's also
+// a reasonable default for the rest of the AX series.
+var axControlTable = map[registerID]*Register{
+	modelNumber:             {address: 0, length: 2, access: ro, cacheable: true},
+	firmwareVersion:         {address: 2, length: 1, access: ro, cacheable: true},
+	servoID:                 {address: 3, length: 1, access: rw, cacheable: true, min: 0, max: 252},
+	baudRate:                {address: 4, length: 1, access: rw, cacheable: true, min: 0, max: 254},
+	returnDelayTime:         {address: 5, length: 1, access: rw, cacheable: true, min: 0, max: 254},
+	cwAngleLimit:            {address: 6, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	ccwAngleLimit:           {address: 8, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	highestLimitTemperature: {address: 11, length: 1, access: rw, cacheable: true, min: 0, max: 150},
+	lowestLimitVoltage:      {address: 12, length: 1, access: rw, cacheable: true, min: 50, max: 250},
+	highestLimitVoltage:     {address: 13, length: 1, access: rw, cacheable: true, min: 50, max: 250},
+	maxTorque:               {address: 14, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	statusReturnLevel:       {address: 16, length: 1, access: rw, cacheable: true, min: 0, max: 2},
+	alarmLed:                {address: 17, length: 1, access: rw, cacheable: true, min: 0, max: 127},
+	alarmShutdown:           {address: 18, length: 1, access: rw, cacheable: true, min: 0, max: 127},
+	torqueEnable:            {address: 24, length: 1, access: rw, cacheable: true, min: 0, max: 1},
+	led:                     {address: 25, length: 1, access: rw, cacheable: true, min: 0, max: 1},
+	cwComplianceMargin:      {address: 26, length: 1, access: rw, cacheable: true, min: 0, max: 255},
+	ccwComplianceMargin:     {address: 27, length: 1, access: rw, cacheable: true, min: 0, max: 255},
+	cwComplianceSlope:       {address: 28, length: 1, access: rw, cacheable: true, min: 0, max: 254},
+	ccwComplianceSlope:      {address: 29, length: 1, access: rw, cacheable: true, min: 0, max: 254},
+	goalPosition:            {address: 30, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	movingSpeed:             {address: 32, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	torqueLimit:             {address: 34, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	presentPosition:         {address: 36, length: 2, access: ro, cacheable: true},
+	presentSpeed:            {address: 38, length: 2, access: ro, cacheable: true},
+	presentLoad:             {address: 40, length: 2, access: ro, cacheable: true},
+	presentVoltage:          {address: 42, length: 1, access: ro, cacheable: true},
+	presentTemperature:      {address: 43, length: 1, access: ro, cacheable: true},
+	registered:              {address: 44, length: 1, access: ro, cacheable: true},
+	moving:                  {address: 46, length: 1, access: ro, cacheable: true},
+	lock:                    {address: 47, length: 1, access: rw, cacheable: true, min: 0, max: 1},
+	punch:                   {address: 48, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+}
+
+// xlControlTable is the XL-320 control table (robotis e-Manual, XL-320
+// "Control Table"). XL-320 shares AX/MX's overall register *names* but not
+// their addresses or ranges: several RAM registers shift down to make room
+// for the built-in PID gains, and there's no separate alarm LED register
+// (Shutdown covers both). Registers
+// XL-320 doesn't have at all (compliance margin/slope, a standalone alarm
+// LED, EEPROM lock) are simply omitted - servo.reg already reports those as
+// "register not present" rather than silently reading the wrong byte.
+var xlControlTable = map[registerID]*Register{
+	modelNumber:             {address: 0, length: 2, access: ro, cacheable: true},
+	firmwareVersion:         {address: 2, length: 1, access: ro, cacheable: true},
+	servoID:                 {address: 3, length: 1, access: rw, cacheable: true, min: 0, max: 252},
+	baudRate:                {address: 4, length: 1, access: rw, cacheable: true, min: 0, max: 3},
+	returnDelayTime:         {address: 5, length: 1, access: rw, cacheable: true, min: 0, max: 254},
+	cwAngleLimit:            {address: 6, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	ccwAngleLimit:           {address: 8, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	highestLimitTemperature: {address: 12, length: 1, access: rw, cacheable: true, min: 0, max: 150},
+	lowestLimitVoltage:      {address: 13, length: 1, access: rw, cacheable: true, min: 50, max: 250},
+	highestLimitVoltage:     {address: 14, length: 1, access: rw, cacheable: true, min: 50, max: 250},
+	maxTorque:               {address: 15, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	statusReturnLevel:       {address: 17, length: 1, access: rw, cacheable: true, min: 0, max: 2},
+	alarmShutdown:           {address: 18, length: 1, access: rw, cacheable: true, min: 0, max: 127},
+	torqueEnable:            {address: 24, length: 1, access: rw, cacheable: true, min: 0, max: 1},
+	led:                     {address: 25, length: 1, access: rw, cacheable: true, min: 0, max: 1},
+	goalPosition:            {address: 31, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	movingSpeed:             {address: 33, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	torqueLimit:             {address: 35, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+	presentPosition:         {address: 37, length: 2, access: ro, cacheable: true},
+	presentSpeed:            {address: 39, length: 2, access: ro, cacheable: true},
+	presentLoad:             {address: 41, length: 2, access: ro, cacheable: true},
+	presentVoltage:          {address: 45, length: 1, access: ro, cacheable: true},
+	presentTemperature:      {address: 46, length: 1, access: ro, cacheable: true},
+	registered:              {address: 47, length: 1, access: ro, cacheable: true},
+	moving:                  {address: 49, length: 1, access: ro, cacheable: true},
+	punch:                   {address: 51, length: 2, access: rw, cacheable: true, min: 0, max: 1023},
+}
+
+// registerNames maps each registerID to the identifier string used in
+// Dictionary files and the generic Register/ReadReg/WriteReg API.
+var registerNames = map[registerID]string{
+	modelNumber:             "modelNumber",
+	firmwareVersion:         "firmwareVersion",
+	servoID:                 "servoID",
+	baudRate:                "baudRate",
+	returnDelayTime:         "returnDelayTime",
+	cwAngleLimit:            "cwAngleLimit",
+	ccwAngleLimit:           "ccwAngleLimit",
+	highestLimitTemperature: "highestLimitTemperature",
+	lowestLimitVoltage:      "lowestLimitVoltage",
+	highestLimitVoltage:     "highestLimitVoltage",
+	maxTorque:               "maxTorque",
+	statusReturnLevel:       "statusReturnLevel",
+	alarmLed:                "alarmLed",
+	alarmShutdown:           "alarmShutdown",
+	torqueEnable:            "torqueEnable",
+	led:                     "led",
+	cwComplianceMargin:      "cwComplianceMargin",
+	ccwComplianceMargin:     "ccwComplianceMargin",
+	cwComplianceSlope:       "cwComplianceSlope",
+	ccwComplianceSlope:      "ccwComplianceSlope",
+	goalPosition:            "goalPosition",
+	movingSpeed:             "movingSpeed",
+	torqueLimit:             "torqueLimit",
+	presentPosition:         "presentPosition",
+	presentSpeed:            "presentSpeed",
+	presentLoad:             "presentLoad",
+	presentVoltage:          "presentVoltage",
+	presentTemperature:      "presentTemperature",
+	registered:              "registered",
+	moving:                  "moving",
+	lock:                    "lock",
+	punch:                   "punch",
+}
+
+// registerIDByName is the reverse of registerNames, used when loading a
+// Dictionary to recognise entries that map onto a known registerID.
+var registerIDByName = func() map[string]registerID {
+	m := make(map[string]registerID, len(registerNames))
+	for id, name := range registerNames {
+		m[name] = id
+	}
+	return m
+}()
+
+// tableSizeOf returns the number of bytes needed to cache every register in
+// table, i.e. the highest (address + length) of any entry.
+func tableSizeOf(table map[registerID]*Register) int {
+	size := 0
+	for _, reg := range table {
+		if end := int(reg.address) + reg.length; end > size {
+			size = end
+		}
+	}
+	return size
+}
@@ -6,82 +6,122 @@ import (
 	"math"
 )
 
-const (
-
-	// Control table size (in bytes)
-	// TODO: Instead of hard-coding this, maybe calculate the size by finding the
-	//       highest register address and adding its length?
-	tableSize = 50
-
-	// Control Table Addresses (EEPROM)
-	addrID                byte = 0x03 // 1
-	addrStatusReturnLevel byte = 0x10 // 1
-
-	// Control Table Addresses (RAM, Read/Write)
-	addrGoalPosition byte = 0x1E // 2
-	addrTorqueLimit  byte = 0x22 // 2
-
-	// Limits (from dxl_ax_actuator.htm)
-	// TODO: Move these to the registValers
-	maxPos   uint16  = 1023
-	maxSpeed uint16  = 1023
-	maxAngle float64 = 300
-
-	// Unit conversions
-	positionToAngle float64 = maxAngle / float64(maxPos) // 0.293255132
-	angleToPosition float64 = 1 / positionToAngle        // 3.41
-)
-
 type DynamixelServo struct {
 	Network   Networker
 	Ident     uint8
 	zeroAngle float64
 
-	// Cache of control table values
-	cache [tableSize]byte
+	// model describes the control table, position/angle ranges and wire
+	// protocol of the servo this instance is talking to.
+	model Model
+
+	// Cache of control table values, sized to fit model's control table.
+	cache []byte
 
 	// TODO: Remove this attribute in favor of reading the value from the control
 	//       table cache.
 	statusReturnLevel int
+
+	// events, when non-nil, is fed by the background poller started by
+	// Events(). stopPolling cancels that poller.
+	events      chan Event
+	stopPolling chan struct{}
+
+	// logger receives Debug/Info/Warn/Error messages about this servo. It
+	// defaults to NopLogger; override it with SetLogger.
+	logger Logger
 }
 
-// NewServo returns a new DynamixelServo with its cache populated.
-// TODO: Return a pointer, error tuple! We're currently ignoring the return
-//       value of the updateCache call.
-func NewServo(network Networker, ident uint8) *DynamixelServo {
+// NewServo returns a new DynamixelServo driven by model, with its cache
+// populated from the servo's current control table values.
+func NewServo(network Networker, model Model, ident uint8) (*DynamixelServo, error) {
+	size := tableSizeOf(model.ControlTable())
+	if dm, ok := model.(*DictionaryModel); ok {
+		size = dm.Dictionary().TableSize()
+	}
+
 	s := &DynamixelServo{
 		Network:           network,
 		Ident:             ident,
+		model:             model,
+		cache:             make([]byte, size),
 		zeroAngle:         150,
 		statusReturnLevel: 2,
+		logger:            NopLogger{},
+	}
+
+	if err := s.updateCache(); err != nil {
+		return nil, err
 	}
+	return s, nil
+}
+
+// SetLogger overrides the servo's own Logger, used by its debugf/infof/
+// warnf/errorf helpers. It does not touch the Networker's logger: Network is
+// typically shared by every servo on the same bus, so changing it here would
+// silently override logging for every other servo sharing it too. Set the
+// Networker's logger directly (via its own SetLogger) for transport-level
+// logging.
+func (servo *DynamixelServo) SetLogger(logger Logger) {
+	servo.logger = logger
+}
+
+func (servo *DynamixelServo) debugf(format string, v ...interface{}) {
+	servo.logger.Debugf("servo[%d]. "+format, append([]interface{}{servo.Ident}, v...)...)
+}
+
+func (servo *DynamixelServo) infof(format string, v ...interface{}) {
+	servo.logger.Infof("servo[%d]. "+format, append([]interface{}{servo.Ident}, v...)...)
+}
 
-	_ = s.updateCache()
-	return s
+func (servo *DynamixelServo) warnf(format string, v ...interface{}) {
+	servo.logger.Warnf("servo[%d]. "+format, append([]interface{}{servo.Ident}, v...)...)
+}
+
+func (servo *DynamixelServo) errorf(format string, v ...interface{}) {
+	servo.logger.Errorf("servo[%d]. "+format, append([]interface{}{servo.Ident}, v...)...)
+}
+
+// Model returns the Model driving this servo.
+func (servo *DynamixelServo) Model() Model {
+	return servo.model
+}
+
+// Protocol returns the wire protocol adapter for this servo's Model, for
+// Networker implementations (or callers building their own transport) that
+// need to frame an instruction by hand.
+func (servo *DynamixelServo) Protocol() Protocol {
+	return servo.model.Protocol()
 }
 
 // updateCache reads the entire control table from the servo, and stores it in
 // the cache.
 func (servo *DynamixelServo) updateCache() error {
-	b, err := servo.Network.ReadData(servo.Ident, 0x0, tableSize)
+	size := len(servo.cache)
+
+	b, err := servo.Network.ReadData(servo.Ident, 0x0, size, servo.Protocol())
 	if err != nil {
 		return err
 	}
 
 	// Ensure that the returned slice is the right size.
-	if len(b) != tableSize {
-		return fmt.Errorf("invalid control table size: %d (expected %d)", len(b), tableSize)
-	}
-
-	// Copy each byte to the cache.
-	// TODO: Surely there is a better way to do this.
-	for i := 0; i < tableSize; i++ {
-		servo.cache[i] = b[i]
+	if len(b) != size {
+		return fmt.Errorf("invalid control table size: %d (expected %d)", len(b), size)
 	}
 
+	copy(servo.cache, b)
 	return nil
 }
 
+// reg looks up a register by id in the servo's model-specific control table.
+func (servo *DynamixelServo) reg(id registerID) (Register, error) {
+	reg, ok := servo.model.ControlTable()[id]
+	if !ok {
+		return Register{}, fmt.Errorf("register not present on %s: %v", servo.model.Name(), id)
+	}
+	return *reg, nil
+}
+
 // getRegister fetches the value of a register from the cache.
 func (servo *DynamixelServo) getRegister(reg Register) (int, error) {
 	if reg.length != 1 && reg.length != 2 {
@@ -101,10 +141,12 @@ func (servo *DynamixelServo) getRegister(reg Register) (int, error) {
 			return 0, errors.New("can't READ while Status Return Level is zero")
 		}
 
-		b, err := servo.Network.ReadData(servo.Ident, reg.address, reg.length)
+		b, err := servo.Network.ReadData(servo.Ident, reg.address, reg.length, servo.Protocol())
 		if err != nil {
+			servo.errorf("read failed for register@0x%02X: %v", reg.address, err)
 			return 0, err
 		}
+		servo.debugf("read register@0x%02X: %v", reg.address, b)
 
 		switch len(b) {
 		case 1:
@@ -127,18 +169,21 @@ func (servo *DynamixelServo) getRegister(reg Register) (int, error) {
 // register is read only or if the write failed.
 func (servo *DynamixelServo) setRegister(reg Register, value int) error {
 	if reg.access == ro {
+		servo.warnf("rejected write to read-only register@0x%02X", reg.address)
 		return fmt.Errorf("can't write to a read-only register")
 	}
 
-	if value < reg.min {
-		return fmt.Errorf("value too low: %d (min=%d)", value, reg.min)
-	}
+	if value < reg.min || value > reg.max {
+		servo.warnf("rejected out-of-range write to register@0x%02X: %d (min=%d, max=%d)", reg.address, value, reg.min, reg.max)
 
-	if value > reg.max {
+		if value < reg.min {
+			return fmt.Errorf("value too low: %d (min=%d)", value, reg.min)
+		}
 		return fmt.Errorf("value too high: %d (max=%d)", value, reg.max)
 	}
 
-	// TODO: Add log message when setting a register.
+	before := servo.cache[reg.address]
+
 	switch reg.length {
 	case 1:
 		servo.writeData(reg.address, low(value))
@@ -153,6 +198,8 @@ func (servo *DynamixelServo) setRegister(reg Register, value int) error {
 		return fmt.Errorf("invalid register length: %d", reg.length)
 	}
 
+	servo.debugf("wrote register@0x%02X: %d -> %d (cache byte %d -> %d)", reg.address, before, value, before, servo.cache[reg.address])
+
 	return nil
 }
 
@@ -160,7 +207,7 @@ func (servo *DynamixelServo) setRegister(reg Register, value int) error {
 // nil if the ping succeeds, otherwise an error. It's optional, but a very good
 // idea, to call this before sending any other instructions to the servo.
 func (servo *DynamixelServo) Ping() error {
-	return servo.Network.Ping(servo.Ident)
+	return servo.Network.Ping(servo.Ident, servo.Protocol())
 }
 
 func (servo *DynamixelServo) readInt(addr byte, n int) (int, error) {
@@ -168,7 +215,7 @@ func (servo *DynamixelServo) readInt(addr byte, n int) (int, error) {
 		return 0, errors.New("can't READ while Status Return Level is zero")
 	}
 
-	b, err := servo.Network.ReadData(servo.Ident, addr, n)
+	b, err := servo.Network.ReadData(servo.Ident, addr, n, servo.Protocol())
 	if err != nil {
 		return 0, err
 	}
@@ -178,7 +225,7 @@ func (servo *DynamixelServo) readInt(addr byte, n int) (int, error) {
 
 // TODO: Remove this in favor of setRegister?
 func (servo *DynamixelServo) writeData(params ...byte) error {
-	return servo.Network.WriteData(servo.Ident, (servo.statusReturnLevel == 2), params...)
+	return servo.Network.WriteData(servo.Ident, (servo.statusReturnLevel == 2), servo.Protocol(), params...)
 }
 
 func posDistance(a uint16, b uint16) uint16 {
@@ -246,43 +293,61 @@ func normalizeAngle(d float64) float64 {
 // punch*
 //
 
+// get looks up reg by id and reads its current value, in one step.
+func (servo *DynamixelServo) get(id registerID) (int, error) {
+	reg, err := servo.reg(id)
+	if err != nil {
+		return 0, err
+	}
+	return servo.getRegister(reg)
+}
+
+// set looks up reg by id and writes value to it, in one step.
+func (servo *DynamixelServo) set(id registerID, value int) error {
+	reg, err := servo.reg(id)
+	if err != nil {
+		return err
+	}
+	return servo.setRegister(reg, value)
+}
+
 func (servo *DynamixelServo) ModelNumber() (int, error) {
-	return servo.getRegister(*registers[modelNumber])
+	return servo.get(modelNumber)
 }
 
 func (servo *DynamixelServo) FirmwareVersion() (int, error) {
-	return servo.getRegister(*registers[firmwareVersion])
+	return servo.get(firmwareVersion)
 }
 
 func (servo *DynamixelServo) PresentSpeed() (int, error) {
-	return servo.getRegister(*registers[presentSpeed])
+	return servo.get(presentSpeed)
 }
 
 func (servo *DynamixelServo) TorqueEnable() (bool, error) {
-	v, err := servo.getRegister(*registers[torqueEnable])
+	v, err := servo.get(torqueEnable)
 	return itob(v), err
 }
 
 // Enables or disables torque.
 func (servo *DynamixelServo) SetTorqueEnable(state bool) error {
-	servo.logMethod("SetTorqueEnable(%t)", state)
-	return servo.setRegister(*registers[torqueEnable], btoi(state))
+	servo.debugf("SetTorqueEnable(%t)", state)
+	return servo.set(torqueEnable, btoi(state))
 }
 
 // LED returns the current state of the servo's LED.
 // TODO: Should we continue to return bool here, or expose the int?
 func (servo *DynamixelServo) LED() (bool, error) {
-	v, err := servo.getRegister(*registers[led])
+	v, err := servo.get(led)
 	return itob(v), err
 }
 
 // Enables or disables the servo's LED.
 func (servo *DynamixelServo) SetLED(state bool) error {
-	return servo.setRegister(*registers[led], btoi(state))
+	return servo.set(led, btoi(state))
 }
 
 func (servo *DynamixelServo) GoalPosition() (int, error) {
-	return servo.getRegister(*registers[goalPosition])
+	return servo.get(goalPosition)
 }
 
 // SetGoalPosition sets the goal position.
@@ -291,7 +356,10 @@ func (servo *DynamixelServo) SetGoalPosition(pos int) error {
 	// TODO: Reject if the servo is in wheel mode (where CW and CCW angle limit
 	//       is zero).
 
-	reg := *registers[goalPosition]
+	reg, err := servo.reg(goalPosition)
+	if err != nil {
+		return err
+	}
 
 	if pos < reg.min || pos > reg.max {
 		return errors.New("goal position out of range")
@@ -303,45 +371,50 @@ func (servo *DynamixelServo) SetGoalPosition(pos int) error {
 // MovingSpeed returns the current moving speed. This is not the speed at which
 // the motor is moving, it's the speed at which the servo wants to move.
 func (servo *DynamixelServo) MovingSpeed() (int, error) {
-	return servo.getRegister(*registers[movingSpeed])
+	return servo.get(movingSpeed)
 }
 
 // Sets the moving speed.
 func (servo *DynamixelServo) SetMovingSpeed(speed int) error {
-	if speed < 0 || speed > int(maxSpeed) {
+	reg, err := servo.reg(movingSpeed)
+	if err != nil {
+		return err
+	}
+
+	if speed < reg.min || speed > reg.max {
 		return errors.New("moving speed out of range")
 	}
 
-	return servo.setRegister(*registers[movingSpeed], speed)
+	return servo.setRegister(reg, speed)
 }
 
 func (servo *DynamixelServo) PresentPosition() (int, error) {
-	return servo.getRegister(*registers[presentPosition])
+	return servo.get(presentPosition)
 }
 
 func (servo *DynamixelServo) PresentVoltage() (int, error) {
-	return servo.getRegister(*registers[presentVoltage])
+	return servo.get(presentVoltage)
 }
 
 func (servo *DynamixelServo) PresentLoad() (int, error) {
-	return servo.getRegister(*registers[presentLoad])
+	return servo.get(presentLoad)
 }
 
 func (servo *DynamixelServo) PresentTemperature() (int, error) {
-	return servo.getRegister(*registers[presentTemperature])
+	return servo.get(presentTemperature)
 }
 
 func (servo *DynamixelServo) Registered() (int, error) {
-	return servo.getRegister(*registers[registered])
+	return servo.get(registered)
 }
 
 func (servo *DynamixelServo) Moving() (int, error) {
-	return servo.getRegister(*registers[moving])
+	return servo.get(moving)
 }
 
 // TODO: Rename this to avoid confusion?
 func (servo *DynamixelServo) Lock() (int, error) {
-	return servo.getRegister(*registers[lock])
+	return servo.get(lock)
 }
 
 func (servo *DynamixelServo) SetLock(isLocked int) error {
@@ -356,7 +429,10 @@ func (servo *DynamixelServo) SetLock(isLocked int) error {
 	//       we risk accidentally (in the case of a bug) reading from the actual
 	//       device, which would be slow and weird.
 
-	reg := *registers[lock]
+	reg, err := servo.reg(lock)
+	if err != nil {
+		return err
+	}
 
 	if isLocked == 0 && servo.cache[reg.address] == byte(1) {
 		return errors.New("EEPROM can't be unlocked; must be power-cycled")
@@ -372,11 +448,15 @@ func (servo *DynamixelServo) SetLock(isLocked int) error {
 //    servo as possible.
 
 func (servo *DynamixelServo) posToAngle(pos int) float64 {
-	return (positionToAngle * float64(pos)) - servo.zeroAngle
+	_, maxPos := servo.model.PositionRange()
+	_, maxAngle := servo.model.AngleRange()
+	return (maxAngle/float64(maxPos))*float64(pos) - servo.zeroAngle
 }
 
 func (servo *DynamixelServo) angleToPos(angle float64) int {
-	return int((servo.zeroAngle + angle) * angleToPosition)
+	_, maxPos := servo.model.PositionRange()
+	_, maxAngle := servo.model.AngleRange()
+	return int((servo.zeroAngle + angle) * (float64(maxPos) / maxAngle))
 }
 
 // Sets the origin angle (in degrees).
@@ -419,12 +499,16 @@ func (servo *DynamixelServo) MoveTo(angle float64) error {
 
 // Sets the torque limit.
 func (servo *DynamixelServo) SetTorqueLimit(limit int) error {
-	servo.logMethod("SetTorqueLimit(%d)", limit)
+	servo.debugf("SetTorqueLimit(%d)", limit)
 
 	if limit < 0 || limit > 1023 {
 		return errors.New("torque limit out of range")
 	}
-	return servo.writeData(addrTorqueLimit, low(limit), high(limit))
+	reg, err := servo.reg(torqueLimit)
+	if err != nil {
+		return err
+	}
+	return servo.writeData(reg.address, low(limit), high(limit))
 }
 
 // Sets the status return level. Possible values are:
@@ -439,7 +523,7 @@ func (servo *DynamixelServo) SetTorqueLimit(limit int) error {
 //
 // See: dxl_ax_actuator.htm#Actuator_Address_10
 func (servo *DynamixelServo) SetStatusReturnLevel(value int) error {
-	servo.logMethod("SetStatusReturnLevel(%d)", value)
+	servo.debugf("SetStatusReturnLevel(%d)", value)
 
 	if value < 0 || value > 2 {
 		return fmt.Errorf("invalid Status Return Level value: %d", value)
@@ -449,7 +533,12 @@ func (servo *DynamixelServo) SetStatusReturnLevel(value int) error {
 	// the return status level will depend upon the new level, rather than the
 	// current level cache. We don't want to update that until we're sure that
 	// the write was successful.
-	err := servo.Network.WriteData(servo.Ident, (value == 2), addrStatusReturnLevel, low(value))
+	reg, err := servo.reg(statusReturnLevel)
+	if err != nil {
+		return err
+	}
+
+	err = servo.Network.WriteData(servo.Ident, (value == 2), servo.Protocol(), reg.address, low(value))
 	if err != nil {
 		return err
 	}
@@ -461,14 +550,19 @@ func (servo *DynamixelServo) SetStatusReturnLevel(value int) error {
 // Changes the identity of the servo.
 // This is stored in EEPROM, so will persist between reboots.
 func (servo *DynamixelServo) SetIdent(ident int) error {
-	servo.logMethod("SetIdent(%d, %d)", ident)
+	servo.debugf("SetIdent(%d, %d)", ident)
 	i := low(ident)
 
 	if i < 0 || i > 252 {
 		return fmt.Errorf("invalid ID (must be 0-252): %d", i)
 	}
 
-	err := servo.writeData(addrID, i)
+	reg, err := servo.reg(servoID)
+	if err != nil {
+		return err
+	}
+
+	err = servo.writeData(reg.address, i)
 	if err != nil {
 		return err
 	}
@@ -494,7 +588,3 @@ func (servo *DynamixelServo) Position() (int, error) {
 	return servo.PresentPosition()
 }
 
-func (servo *DynamixelServo) logMethod(format string, v ...interface{}) {
-	prefix := fmt.Sprintf("servo[%d].", servo.Ident)
-	servo.Network.Log(prefix+format, v...)
-}
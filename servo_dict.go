@@ -0,0 +1,90 @@
+package dynamixel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Register looks up a register by its dictionary identifier (e.g.
+// "goalPosition"), for models backed by a Dictionary. The second return
+// value is false if the servo's model isn't dictionary-backed, or doesn't
+// have a register by that name.
+func (servo *DynamixelServo) Register(id string) (Register, bool) {
+	dm, ok := servo.model.(*DictionaryModel)
+	if !ok {
+		return Register{}, false
+	}
+
+	e, ok := dm.Dictionary().Entry(id)
+	if !ok {
+		return Register{}, false
+	}
+	return e.Register(), true
+}
+
+// ReadReg reads a register by its dictionary identifier, returning its value
+// in engineering units (after applying the entry's scale and offset).
+func (servo *DynamixelServo) ReadReg(id string) (float64, error) {
+	dm, ok := servo.model.(*DictionaryModel)
+	if !ok {
+		return 0, fmt.Errorf("servo's model doesn't have a dictionary")
+	}
+
+	e, ok := dm.Dictionary().Entry(id)
+	if !ok {
+		return 0, fmt.Errorf("unknown register: %s", id)
+	}
+
+	raw, err := servo.getRegister(e.Register())
+	if err != nil {
+		return 0, err
+	}
+
+	return e.toEngineering(raw), nil
+}
+
+// WriteReg writes value (in engineering units) to a register by its
+// dictionary identifier, converting it to a raw value first.
+func (servo *DynamixelServo) WriteReg(id string, value float64) error {
+	dm, ok := servo.model.(*DictionaryModel)
+	if !ok {
+		return fmt.Errorf("servo's model doesn't have a dictionary")
+	}
+
+	e, ok := dm.Dictionary().Entry(id)
+	if !ok {
+		return fmt.Errorf("unknown register: %s", id)
+	}
+
+	return servo.setRegister(e.Register(), e.toRaw(value))
+}
+
+// ExportDictionary writes a JSON Dictionary describing the servo's model to
+// w. For dictionary-backed models this is the dictionary it was loaded
+// from; for built-in models it's synthesized from their control table, so
+// that tooling can always get a dictionary to start from.
+func (servo *DynamixelServo) ExportDictionary(w io.Writer) error {
+	if dm, ok := servo.model.(*DictionaryModel); ok {
+		return json.NewEncoder(w).Encode(dm.Dictionary())
+	}
+
+	dict := Dictionary{Name: servo.model.Name()}
+	for id, reg := range servo.model.ControlTable() {
+		name, ok := registerNames[id]
+		if !ok {
+			continue
+		}
+		dict.Entries = append(dict.Entries, DictEntry{
+			Identifier: name,
+			Address:    reg.address,
+			Length:     reg.length,
+			Access:     reg.access,
+			Cacheable:  reg.cacheable,
+			Min:        reg.min,
+			Max:        reg.max,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(&dict)
+}
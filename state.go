@@ -0,0 +1,330 @@
+package dynamixel
+
+import (
+	"context"
+	"time"
+)
+
+// State is a coarse-grained view of what a servo is doing, derived from its
+// torque, motion and alarm registers - modeled on IngeniaLink's SERVO_STATE.
+type State int
+
+const (
+	// Disabled is the zero State. State() never derives it: a servo with
+	// torque off and no alarm active reports ReadyToSwitchOn.
+	Disabled State = iota
+
+	// ReadyToSwitchOn means torque is off, but the servo is healthy and
+	// could be enabled.
+	ReadyToSwitchOn
+
+	// On means torque is enabled but the servo isn't currently moving.
+	On
+
+	// Enabled means torque is enabled and the servo is moving toward its
+	// goal.
+	Enabled
+
+	// QuickStop means the servo is decelerating to a stop. AX-class servos
+	// have no register that reports this, so State() never returns it.
+	QuickStop
+
+	// FaultReactive means an alarm is active but torque hasn't shut off
+	// yet.
+	FaultReactive
+
+	// Fault means an alarm shut the servo's torque off.
+	Fault
+)
+
+// Flags are independent conditions that can be true regardless of State -
+// modeled on IngeniaLink's SERVO_FLAGS.
+type Flags int
+
+const (
+	// TargetReached means PresentPosition is within tolerance of
+	// GoalPosition (or the servo reports it's no longer moving).
+	TargetReached Flags = 1 << iota
+
+	// InternalLimitActive means the alarmShutdown register is non-zero.
+	// presentLoad, presentVoltage and presentTemperature are not consulted.
+	InternalLimitActive
+
+	// HomingAttained means the servo has reached its reference position.
+	// AX-class servos don't have a distinct homing procedure, so this
+	// mirrors TargetReached for them.
+	HomingAttained
+)
+
+// EventType identifies what kind of Event was emitted.
+type EventType int
+
+const (
+	// EventFault fires when State transitions into Fault.
+	EventFault EventType = iota
+
+	// EventFaultCleared fires when State transitions out of Fault.
+	EventFaultCleared
+
+	// EventTargetReached fires when the TargetReached flag sets.
+	EventTargetReached
+)
+
+// Event describes a state or flag transition observed by the background
+// poller started by Events().
+type Event struct {
+	Type    EventType
+	State   State
+	Flags   Flags
+	Message string
+}
+
+// State derives the servo's State and Flags from its control table values:
+// torqueEnable, alarmShutdown, moving, and (for TargetReached)
+// GoalPosition/PresentPosition. presentLoad, presentVoltage and
+// presentTemperature are not read.
+func (servo *DynamixelServo) State() (State, Flags, error) {
+	torque, err := servo.TorqueEnable()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	alarm, err := servo.get(alarmShutdown)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var flags Flags
+
+	reached, err := servo.targetReached(0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if reached {
+		flags |= TargetReached | HomingAttained
+	}
+
+	if alarm != 0 {
+		flags |= InternalLimitActive
+		if !torque {
+			return Fault, flags, nil
+		}
+		return FaultReactive, flags, nil
+	}
+
+	if !torque {
+		return ReadyToSwitchOn, flags, nil
+	}
+
+	moving, err := servo.Moving()
+	if err != nil {
+		return 0, 0, err
+	}
+	if moving != 0 {
+		return Enabled, flags, nil
+	}
+
+	return On, flags, nil
+}
+
+// targetReached reports whether PresentPosition is within tolerance steps of
+// the cached GoalPosition, or the servo reports it's no longer moving.
+func (servo *DynamixelServo) targetReached(tolerance int) (bool, error) {
+	moving, err := servo.Moving()
+	if err != nil {
+		return false, err
+	}
+	if moving == 0 {
+		return true, nil
+	}
+
+	goal, err := servo.GoalPosition()
+	if err != nil {
+		return false, err
+	}
+
+	pos, err := servo.PresentPosition()
+	if err != nil {
+		return false, err
+	}
+
+	return int(posDistance(uint16(goal), uint16(pos))) <= tolerance, nil
+}
+
+// WaitForTargetReached blocks until PresentPosition is within tolerance
+// steps of GoalPosition (or Moving clears), or ctx is done.
+func (servo *DynamixelServo) WaitForTargetReached(ctx context.Context, tolerance int) error {
+	return servo.pollUntil(ctx, func() (bool, error) {
+		return servo.targetReached(tolerance)
+	})
+}
+
+// WaitForHoming blocks until the servo's HomingAttained flag sets, or ctx is
+// done. AX-class servos don't support a distinct homing procedure, so this
+// is equivalent to WaitForTargetReached(ctx, 0).
+func (servo *DynamixelServo) WaitForHoming(ctx context.Context) error {
+	return servo.WaitForTargetReached(ctx, 0)
+}
+
+// WaitForFaultClear blocks until the servo's alarm register clears, or ctx
+// is done.
+func (servo *DynamixelServo) WaitForFaultClear(ctx context.Context) error {
+	return servo.pollUntil(ctx, func() (bool, error) {
+		_, flags, err := servo.State()
+		if err != nil {
+			return false, err
+		}
+		return flags&InternalLimitActive == 0, nil
+	})
+}
+
+// pollUntil polls condition at a fixed interval until it returns true, it
+// errors, or ctx is done.
+func (servo *DynamixelServo) pollUntil(ctx context.Context, condition func() (bool, error)) error {
+	const pollInterval = 50 * time.Millisecond
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := condition()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recover clears a fault: it clears the alarm shutdown register, re-enables
+// torque, and rewrites the cached RAM registers (GoalPosition, MovingSpeed,
+// TorqueLimit) so the servo picks up where the cache says it should be,
+// rather than wherever the fault left it.
+func (servo *DynamixelServo) Recover() error {
+	servo.debugf("Recover()")
+
+	if err := servo.set(alarmShutdown, 0); err != nil {
+		return err
+	}
+
+	if err := servo.SetTorqueEnable(true); err != nil {
+		return err
+	}
+
+	for _, id := range []registerID{goalPosition, movingSpeed, torqueLimit} {
+		reg, err := servo.reg(id)
+		if err != nil {
+			return err
+		}
+
+		v, err := servo.getRegister(reg)
+		if err != nil {
+			return err
+		}
+
+		if err := servo.setRegister(reg, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Events starts a background poller and returns a channel fed with Events as
+// the servo's State and Flags change. The channel is closed when ctx is done,
+// or when StopEvents is called. Until StopEvents is called, later calls
+// return that same channel without starting a new poller, even if it has
+// been closed because its ctx is done.
+func (servo *DynamixelServo) Events(ctx context.Context) (<-chan Event, error) {
+	if servo.events != nil {
+		return servo.events, nil
+	}
+
+	ch := make(chan Event, 16)
+	stop := make(chan struct{})
+	servo.events = ch
+	servo.stopPolling = stop
+
+	go servo.pollEvents(ctx, ch, stop)
+
+	return ch, nil
+}
+
+// pollEvents is the background poller started by Events.
+func (servo *DynamixelServo) pollEvents(ctx context.Context, ch chan Event, stop chan struct{}) {
+	defer close(ch)
+
+	const pollInterval = 100 * time.Millisecond
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var lastState State
+	var lastFlags Flags
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		state, flags, err := servo.State()
+		if err != nil {
+			continue
+		}
+
+		if state == Fault && lastState != Fault {
+			servo.warnf("alarm shutdown active")
+			if !servo.sendEvent(ctx, ch, stop, Event{Type: EventFault, State: state, Flags: flags, Message: "alarm shutdown active"}) {
+				return
+			}
+		} else if state != Fault && lastState == Fault {
+			servo.infof("alarm cleared")
+			if !servo.sendEvent(ctx, ch, stop, Event{Type: EventFaultCleared, State: state, Flags: flags}) {
+				return
+			}
+		}
+
+		if flags&TargetReached != 0 && lastFlags&TargetReached == 0 {
+			if !servo.sendEvent(ctx, ch, stop, Event{Type: EventTargetReached, State: state, Flags: flags}) {
+				return
+			}
+		}
+
+		lastState, lastFlags = state, flags
+	}
+}
+
+// sendEvent delivers ev on ch, unless ctx is done or stop fires first - so a
+// consumer that's stopped draining Events()'s channel can't deadlock the
+// poller. Returns false if the poller should stop instead of delivering.
+func (servo *DynamixelServo) sendEvent(ctx context.Context, ch chan Event, stop chan struct{}, ev Event) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-stop:
+		return false
+	}
+}
+
+// StopEvents stops the background poller started by Events, closing its
+// channel. It's a no-op if Events was never called (or was already stopped).
+func (servo *DynamixelServo) StopEvents() {
+	if servo.stopPolling == nil {
+		return
+	}
+	close(servo.stopPolling)
+	servo.stopPolling = nil
+	servo.events = nil
+}
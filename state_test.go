@@ -0,0 +1,101 @@
+package dynamixel
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestServo(t *testing.T) *DynamixelServo {
+	t.Helper()
+
+	net := newFakeNetworker(tableSizeOf(AX12A.ControlTable()))
+	servo, err := NewServo(net, AX12A, 1)
+	if err != nil {
+		t.Fatalf("NewServo: %v", err)
+	}
+	return servo
+}
+
+func TestStateDerivation(t *testing.T) {
+	servo := newTestServo(t)
+
+	// torqueEnable defaults to 0 in a fresh control table: ReadyToSwitchOn.
+	state, _, err := servo.State()
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	if state != ReadyToSwitchOn {
+		t.Errorf("State() = %v, want ReadyToSwitchOn", state)
+	}
+
+	if err := servo.SetTorqueEnable(true); err != nil {
+		t.Fatalf("SetTorqueEnable: %v", err)
+	}
+
+	// moving defaults to 0, so targetReached is already true: On.
+	state, flags, err := servo.State()
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	if state != On {
+		t.Errorf("State() = %v, want On", state)
+	}
+	if flags&TargetReached == 0 {
+		t.Errorf("flags = %v, want TargetReached set", flags)
+	}
+
+	if err := servo.set(alarmShutdown, 4); err != nil {
+		t.Fatalf("set(alarmShutdown): %v", err)
+	}
+	state, flags, err = servo.State()
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	if state != FaultReactive {
+		t.Errorf("State() = %v, want FaultReactive", state)
+	}
+	if flags&InternalLimitActive == 0 {
+		t.Errorf("flags = %v, want InternalLimitActive set", flags)
+	}
+}
+
+// TestStopEventsUnblocksPoller covers the chunk0-5 fix: before it, a
+// consumer that stopped draining Events()'s channel could wedge pollEvents
+// on an unguarded send, and there was no way to stop it short of cancelling
+// its context.
+func TestStopEventsUnblocksPoller(t *testing.T) {
+	servo := newTestServo(t)
+
+	logger := NewTestLogger()
+	servo.SetLogger(logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := servo.Events(ctx)
+	if err != nil {
+		t.Fatalf("Events: %v", err)
+	}
+
+	if err := servo.set(alarmShutdown, 4); err != nil {
+		t.Fatalf("set(alarmShutdown): %v", err)
+	}
+
+	// Give the poller time to notice the fault (and log it), then stop
+	// draining the channel before stopping the poller.
+	time.Sleep(300 * time.Millisecond)
+	servo.StopEvents()
+
+	select {
+	case <-ch:
+		// Either a buffered event or the close itself; what matters is
+		// that this doesn't block forever.
+	case <-time.After(2 * time.Second):
+		t.Fatal("Events channel wasn't closed after StopEvents")
+	}
+
+	if len(logger.Messages) == 0 {
+		t.Error("expected the poller's alarm warning to have been logged via the per-servo Logger")
+	}
+}
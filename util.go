@@ -0,0 +1,38 @@
+package dynamixel
+
+import "fmt"
+
+// low returns the low byte of v, for writing a 1- or 2-byte register.
+func low(v int) byte {
+	return byte(v & 0xFF)
+}
+
+// high returns the high (second) byte of v, for writing a 2-byte register.
+func high(v int) byte {
+	return byte((v >> 8) & 0xFF)
+}
+
+// bytesToInt decodes a register value read as 1 or 2 little-endian bytes.
+func bytesToInt(b []byte) (int, error) {
+	switch len(b) {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(b[0]) | int(b[1])<<8, nil
+	default:
+		return 0, fmt.Errorf("expected 1 or 2 bytes, got %d", len(b))
+	}
+}
+
+// itob converts a register's raw 0/1 value to a bool.
+func itob(v int) bool {
+	return v != 0
+}
+
+// btoi converts a bool to the 0/1 value a register expects.
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}